@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kbinani/screenshot"
+)
+
+// Capturer captures individual timelapse frames and stitches a finished
+// frame set into a video file. It lets the recording loop in runTimelapse
+// stay backend-agnostic: the default path never leaves the Go process, but
+// the legacy Python script can still be selected behind the same interface.
+type Capturer interface {
+	// CaptureFrame grabs frameIdx's frame and returns the decoded image.
+	CaptureFrame(ctx context.Context, frameIdx int) (image.Image, error)
+
+	// Encode stitches frames (PNG file paths, in capture order) into out.
+	Encode(frames []string, out string) error
+}
+
+// ScreenCapturer is the default, zero-dependency backend: frames come from
+// an in-process screen grab via kbinani/screenshot, and encoding shells out
+// to a local ffmpeg binary.
+type ScreenCapturer struct {
+	// DisplayIndex selects which display screenshot.CaptureDisplay reads.
+	// Ignored once Region is set.
+	DisplayIndex int
+
+	// Region, if non-zero, crops capture to this sub-rectangle of the
+	// display instead of grabbing it whole.
+	Region image.Rectangle
+
+	// Codec is the ffmpeg -c:v value Encode passes; empty keeps ffmpeg's
+	// own default encoder.
+	Codec string
+}
+
+// NewScreenCapturer returns a ScreenCapturer for the primary display.
+func NewScreenCapturer() *ScreenCapturer {
+	return &ScreenCapturer{DisplayIndex: 0}
+}
+
+func (c *ScreenCapturer) CaptureFrame(ctx context.Context, frameIdx int) (image.Image, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if c.Region != (image.Rectangle{}) {
+		img, err := screenshot.CaptureRect(c.Region)
+		if err != nil {
+			return nil, fmt.Errorf("capture region %v: %w", c.Region, err)
+		}
+		return img, nil
+	}
+
+	img, err := screenshot.CaptureDisplay(c.DisplayIndex)
+	if err != nil {
+		return nil, fmt.Errorf("capture display %d: %w", c.DisplayIndex, err)
+	}
+	return img, nil
+}
+
+func (c *ScreenCapturer) Encode(frames []string, out string) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames to encode")
+	}
+
+	listFile, err := writeConcatList(frames)
+	if err != nil {
+		return fmt.Errorf("prepare ffmpeg input: %w", err)
+	}
+	defer os.Remove(listFile)
+
+	args := []string{
+		"-y",
+		"-f", "concat",
+		"-safe", "0",
+		"-i", listFile,
+		"-pix_fmt", "yuv420p",
+	}
+	if c.Codec != "" {
+		args = append(args, "-c:v", c.Codec)
+	}
+	args = append(args, out)
+
+	cmd := exec.Command("ffmpeg", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("ffmpeg encode: %w (%s)", err, output)
+	}
+	return nil
+}
+
+// writeConcatList writes an ffmpeg concat demuxer script listing frames in
+// order, each shown for one capture interval, and returns its path.
+func writeConcatList(frames []string) (string, error) {
+	f, err := os.CreateTemp("", "chronapse-concat-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	for _, frame := range frames {
+		if _, err := fmt.Fprintf(f, "file '%s'\n", frame); err != nil {
+			return "", err
+		}
+	}
+	return f.Name(), nil
+}
+
+// saveFrame PNG-encodes img to dir/frame-<frameIdx>.png and returns the path.
+func saveFrame(dir string, frameIdx int, img image.Image) (string, error) {
+	path := fmt.Sprintf("%s/frame-%06d.png", dir, frameIdx)
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("create frame file: %w", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		return "", fmt.Errorf("encode frame: %w", err)
+	}
+	return path, nil
+}
+
+// PythonCapturer is the legacy backend. The bundled timelapse.py script
+// owns capture and encoding together and reports progress over its own
+// stdout protocol, so CaptureFrame/Encode only exist to satisfy Capturer;
+// runTimelapse calls run directly for this backend instead of driving it
+// frame-by-frame.
+type PythonCapturer struct{}
+
+// NewPythonCapturer returns the legacy python3 timelapse.py backend.
+func NewPythonCapturer() *PythonCapturer {
+	return &PythonCapturer{}
+}
+
+func (c *PythonCapturer) CaptureFrame(ctx context.Context, frameIdx int) (image.Image, error) {
+	return nil, fmt.Errorf("python backend captures and encodes as one unit; use run")
+}
+
+func (c *PythonCapturer) Encode(frames []string, out string) error {
+	return fmt.Errorf("python backend captures and encodes as one unit; use run")
+}
+
+// fullRunCapturer is implemented by backends that own capture and encoding
+// together instead of being driven frame-by-frame through Capturer.
+// runTimelapse prefers it when a capturer supports it. sink delivers its
+// progress/log messages the same way captureFrameLoop's sink does, so the
+// local TUI and an SSH-served recording can each route them correctly
+// instead of the backend assuming a single global program.
+type fullRunCapturer interface {
+	run(interval, duration float64, output string, sink func(tea.Msg)) tea.Msg
+}
+
+func (c *PythonCapturer) run(interval, duration float64, output string, sink func(tea.Msg)) tea.Msg {
+	cmd := exec.Command(
+		"python3",
+		"timelapse.py",
+		"-i", fmt.Sprintf("%.2f", interval),
+		"-d", fmt.Sprintf("%.2f", duration),
+		"-o", output,
+	)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return completedMsg{success: false, message: fmt.Sprintf("Failed to create stdout pipe: %v", err)}
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return completedMsg{success: false, message: fmt.Sprintf("Failed to create stderr pipe: %v", err)}
+	}
+
+	if err := cmd.Start(); err != nil {
+		return completedMsg{success: false, message: fmt.Sprintf("Failed to start process: %v", err)}
+	}
+
+	go readStdout(stdout, sink)
+	go readStderr(stderr, sink)
+
+	if err := cmd.Wait(); err != nil {
+		return completedMsg{success: false, message: fmt.Sprintf("Recording failed: %v", err)}
+	}
+
+	return completedMsg{success: true, message: fmt.Sprintf("Timelapse saved to: %s", output), outputPath: output}
+}
+
+// readStdout scans pipe for the legacy [PROGRESS] marker protocol and
+// delivers progress/log messages through sink.
+func readStdout(pipe io.ReadCloser, sink func(tea.Msg)) {
+	scanner := bufio.NewScanner(pipe)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.Contains(line, "[PROGRESS]") {
+			// Format: [PROGRESS] 5/120 (4.2%)
+			parts := strings.Fields(line)
+			if len(parts) >= 2 {
+				counts := strings.Split(parts[1], "/")
+				if len(counts) == 2 {
+					current, _ := strconv.Atoi(counts[0])
+					total, _ := strconv.Atoi(counts[1])
+					percent := 0.0
+					if len(parts) >= 3 {
+						percentStr := strings.Trim(parts[2], "(%))")
+						percent, _ = strconv.ParseFloat(percentStr, 64)
+					}
+
+					sink(progressMsg{current, total, percent})
+				}
+			}
+		}
+
+		sink(logMsg(line))
+	}
+}
+
+// readStderr surfaces raw subprocess stderr above the live TUI region via
+// PrintAbove, instead of mixing it into the recording view's log pane.
+func readStderr(pipe io.ReadCloser, sink func(tea.Msg)) {
+	scanner := bufio.NewScanner(pipe)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		sink(printAbove(line)())
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// menuKeyMap describes the bindings available on the input form.
+type menuKeyMap struct {
+	Next  key.Binding
+	Prev  key.Binding
+	Start key.Binding
+	Quit  key.Binding
+}
+
+func (k menuKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Next, k.Start, k.Quit}
+}
+
+func (k menuKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Next, k.Prev},
+		{k.Start, k.Quit},
+	}
+}
+
+var menuKeys = menuKeyMap{
+	Next:  key.NewBinding(key.WithKeys("tab", "down"), key.WithHelp("tab", "next field")),
+	Prev:  key.NewBinding(key.WithKeys("shift+tab", "up"), key.WithHelp("shift+tab", "prev field")),
+	Start: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "start recording")),
+	Quit:  key.NewBinding(key.WithKeys("ctrl+c", "esc"), key.WithHelp("ctrl+c/esc", "quit")),
+}
+
+// recordingKeyMap describes the bindings available while a capture is
+// running, including the scrollable log viewport's follow/select-scroll
+// controls.
+type recordingKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	PgUp   key.Binding
+	PgDown key.Binding
+	Follow key.Binding
+	Pause  key.Binding
+	Resume key.Binding
+	Step   key.Binding
+	Stop   key.Binding
+}
+
+func (k recordingKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Follow, k.Pause, k.Resume, k.Step, k.Stop}
+}
+
+func (k recordingKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.PgUp, k.PgDown},
+		{k.Follow, k.Pause, k.Resume, k.Step},
+		{k.Stop},
+	}
+}
+
+var recordingKeys = recordingKeyMap{
+	Up:     key.NewBinding(key.WithKeys("k", "up"), key.WithHelp("k/↑", "scroll up")),
+	Down:   key.NewBinding(key.WithKeys("j", "down"), key.WithHelp("j/↓", "scroll down")),
+	PgUp:   key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "page up")),
+	PgDown: key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("pgdown", "page down")),
+	Follow: key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "toggle follow")),
+	Pause:  key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pause")),
+	Resume: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "resume")),
+	Step:   key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "step one frame")),
+	Stop:   key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "stop recording")),
+}
+
+// resultKeyMap describes the bindings on the completed/error screens.
+type resultKeyMap struct {
+	Quit key.Binding
+}
+
+func (k resultKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Quit}
+}
+
+func (k resultKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Quit}}
+}
+
+var resultKeys = resultKeyMap{
+	Quit: key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+}
+
+// keyMapFor returns the help.KeyMap relevant to the app's current state, so
+// the footer only ever shows bindings that actually do something.
+func keyMapFor(state appState) help.KeyMap {
+	switch state {
+	case stateRecording:
+		return recordingKeys
+	case stateCompleted, stateError:
+		return resultKeys
+	default:
+		return menuKeys
+	}
+}
@@ -1,21 +1,47 @@
 package main
 
 import (
-	"bufio"
+	"context"
+	"flag"
 	"fmt"
-	"io"
 	"os"
-	"os/exec"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// numInputs is how many textinput fields the menu has (interval, duration,
+// output). The menu's other two focusable elements, the preset list and the
+// start button, sit right after them: presetFocusIndex, then startFocusIndex.
+const numInputs = 3
+const presetFocusIndex = numInputs
+const startFocusIndex = numInputs + 1
+
+// logViewportChrome is how many lines viewRecording renders above the log
+// viewport (title, spinner, progress bar, elapsed time, section header), so
+// WindowSizeMsg can size the viewport to exactly fill the rest of the
+// terminal.
+const logViewportChrome = 12
+
+// progressBarMargin keeps the percent-complete label bubbles/progress draws
+// past the end of the bar from being clipped by the terminal edge.
+const progressBarMargin = 20
+
+// program is the running *tea.Program, set once in main before Run. Code
+// running off the Update goroutine (capture loops, subprocess readers) uses
+// it to deliver typed messages via program.Send instead of returning values
+// that only the bubbletea runtime can act on.
+var program *tea.Program
+
 // Application states
 type appState int
 
@@ -37,11 +63,52 @@ type logMsg string
 type completedMsg struct {
 	success bool
 	message string
+	// outputPath is set on success so viewCompleted can stat the finished
+	// file for the completion summary.
+	outputPath string
 }
 type processExitMsg struct {
 	err error
 }
 
+// quantum mirrors the Gopher2600 debugger's instruction/cycle/clock
+// stepping vocabulary: it's the capture loop's current run state.
+type quantum int
+
+const (
+	quantumRunning quantum = iota
+	quantumPaused
+)
+
+// controlSignal is sent over a recording's control channel to steer its
+// capture loop without killing the underlying capturer.
+type controlSignal int
+
+const (
+	controlPause controlSignal = iota
+	controlResume
+	controlStep
+	controlStop
+)
+
+type pauseMsg struct{}
+type resumeMsg struct{}
+type stepMsg struct{}
+
+// quantumMsg reports the capture loop's run state back to the UI after it
+// acts on a pauseMsg/resumeMsg.
+type quantumMsg quantum
+
+// registryEventMsg wraps a message broadcast by a recorderRegistry so an
+// SSH-served session can tell "update from the shared recording" apart from
+// its own local messages before replaying it through the normal handling.
+type registryEventMsg struct{ msg tea.Msg }
+
+// presetEventMsg wraps a message broadcast by a presetRegistry the same way
+// registryEventMsg wraps recorderRegistry ones, so an SSH-served session
+// picks up presets.toml hot-reloads regardless of what it's doing.
+type presetEventMsg struct{ msg tea.Msg }
+
 // Model represents the application state
 type model struct {
 	state      appState
@@ -51,15 +118,44 @@ type model struct {
 	focusIndex int
 	inputs     []textinput.Model
 	spinner    spinner.Model
-	cmd        *exec.Cmd
+	capturer   Capturer
+	help       help.Model
+
+	// presets holds every profile loaded from presets.toml; presetList is
+	// its bubbles/list selector, kept in sync via presetsReloadedMsg.
+	presets      []Preset
+	presetList   list.Model
+	activePreset string
 
 	// Recording state
-	startTime     time.Time
-	progress      progressMsg
-	logs          []string
-	recordingDone bool
-	finalMessage  string
-	err           error
+	startTime       time.Time
+	captureInterval float64
+	progress        progressMsg
+	progressBar     progress.Model
+	lastFrameTime   time.Time
+	ewmaInterval    time.Duration
+	outputPath      string
+	logs            []string
+	logViewport     viewport.Model
+	follow          bool
+	recordingDone   bool
+	finalMessage    string
+	err             error
+	quantum         quantum
+	controlCh       chan controlSignal
+	pausedDuration  time.Duration
+	pauseStart      time.Time
+
+	// Set only for SSH-served sessions (see serve.go): registry is the
+	// shared recording every session observes, registryCh is this
+	// session's feed from it, authorized gates pause/resume/step/stop, ctx
+	// is the SSH session's context (done once it closes), and presetsCh is
+	// this session's feed from the server's presetRegistry.
+	registry   *recorderRegistry
+	registryCh chan tea.Msg
+	authorized bool
+	ctx        context.Context
+	presetsCh  chan tea.Msg
 }
 
 // Styles
@@ -99,12 +195,19 @@ var (
 			Foreground(lipgloss.Color("#626262"))
 )
 
-func initialModel() model {
+func initialModel(capturer Capturer, presets []Preset) model {
 	m := model{
-		state:   stateMenu,
-		inputs:  make([]textinput.Model, 3),
-		spinner: spinner.New(),
-		logs:    make([]string, 0),
+		state:       stateMenu,
+		inputs:      make([]textinput.Model, numInputs),
+		spinner:     spinner.New(),
+		logs:        make([]string, 0),
+		capturer:    capturer,
+		help:        help.New(),
+		logViewport: viewport.New(80, 20),
+		follow:      true,
+		presets:     presets,
+		presetList:  newPresetList(presets, 40, 8),
+		progressBar: progress.New(progress.WithDefaultGradient()),
 	}
 
 	// Setup interval input
@@ -142,11 +245,38 @@ func initialModel() model {
 }
 
 func (m model) Init() tea.Cmd {
+	if m.presetsCh != nil {
+		return tea.Batch(textinput.Blink, waitForPresets(m.presetsCh))
+	}
 	return textinput.Blink
 }
 
+// PrintAbove prints s above the running TUI, leaving the active view
+// untouched. It's for output that shouldn't pollute the recording view's
+// log pane, such as raw subprocess stderr.
+func (m model) PrintAbove(s string) tea.Cmd {
+	return printAbove(s)
+}
+
+// printAbove is the receiver-free form of model.PrintAbove, for callers
+// (like the subprocess readers) that have no model instance to hand.
+func printAbove(s string) tea.Cmd {
+	return tea.Println(s)
+}
+
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.help.Width = msg.Width
+		m.logViewport.Width = msg.Width
+		m.logViewport.Height = msg.Height - logViewportChrome
+		m.logViewport.SetContent(strings.Join(m.logs, "\n"))
+		m.progressBar.Width = msg.Width - progressBarMargin
+		if m.progressBar.Width < 10 {
+			m.progressBar.Width = 10
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		switch m.state {
 		case stateMenu:
@@ -160,14 +290,58 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case progressMsg:
+		now := time.Now()
+		if !m.lastFrameTime.IsZero() {
+			delta := now.Sub(m.lastFrameTime)
+			if m.ewmaInterval == 0 {
+				m.ewmaInterval = delta
+			} else {
+				const alpha = 0.3 // weight on the newest interval; higher reacts faster, lower smooths jitter more
+				m.ewmaInterval = time.Duration(alpha*float64(delta) + (1-alpha)*float64(m.ewmaInterval))
+			}
+		}
+		m.lastFrameTime = now
 		m.progress = msg
-		return m, nil
+		cmd := m.progressBar.SetPercent(msg.percent / 100)
+		return m, cmd
 
 	case logMsg:
 		m.logs = append(m.logs, string(msg))
-		// Keep only last 5 logs
-		if len(m.logs) > 5 {
-			m.logs = m.logs[1:]
+		m.logViewport.SetContent(strings.Join(m.logs, "\n"))
+		if m.follow {
+			m.logViewport.GotoBottom()
+		}
+		return m, nil
+
+	case pauseMsg:
+		if m.controlCh != nil {
+			m.controlCh <- controlPause
+		}
+		return m, nil
+
+	case resumeMsg:
+		if m.controlCh != nil {
+			m.controlCh <- controlResume
+		}
+		return m, nil
+
+	case stepMsg:
+		if m.controlCh != nil {
+			m.controlCh <- controlStep
+		}
+		return m, nil
+
+	case quantumMsg:
+		m.quantum = quantum(msg)
+		if m.quantum == quantumPaused {
+			m.pauseStart = time.Now()
+		} else if !m.pauseStart.IsZero() {
+			m.pausedDuration += time.Since(m.pauseStart)
+			m.pauseStart = time.Time{}
+			// The next frame's delta shouldn't include the paused gap, or
+			// it spikes the EWMA (and displayed ETA) for the rest of the
+			// recording; treat it like the very first frame instead.
+			m.lastFrameTime = time.Time{}
 		}
 		return m, nil
 
@@ -179,6 +353,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.recordingDone = true
 		m.finalMessage = msg.message
+		m.outputPath = msg.outputPath
 		return m, nil
 
 	case processExitMsg:
@@ -189,11 +364,34 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case registryEventMsg:
+		newModel, cmd := m.Update(msg.msg)
+		nm := newModel.(model)
+		return nm, tea.Batch(cmd, waitForRegistry(m.registryCh))
+
+	case presetEventMsg:
+		newModel, cmd := m.Update(msg.msg)
+		nm := newModel.(model)
+		return nm, tea.Batch(cmd, waitForPresets(m.presetsCh))
+
+	case presetsReloadedMsg:
+		if msg.err != nil {
+			return m, m.PrintAbove(fmt.Sprintf("presets: %v", msg.err))
+		}
+		m.presets = msg.presets
+		cmd := m.presetList.SetItems(presetItems(msg.presets))
+		return m, cmd
+
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
+	case progress.FrameMsg:
+		progressModel, cmd := m.progressBar.Update(msg)
+		m.progressBar = progressModel.(progress.Model)
+		return m, cmd
+
 	case tickMsg:
 		return m, tick()
 	}
@@ -206,56 +404,134 @@ func (m model) updateMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "ctrl+c", "esc":
 		return m, tea.Quit
 
-	case "tab", "shift+tab", "enter", "up", "down":
-		s := msg.String()
-
-		if s == "enter" {
-			// Start recording
-			return m.startRecording()
+	case "enter":
+		if m.focusIndex == presetFocusIndex {
+			if p, ok := m.presetList.SelectedItem().(Preset); ok {
+				m = m.applyPreset(p)
+			}
+		}
+		return m.startRecording()
+
+	case "up", "down":
+		// The preset list owns up/down for its own selection while focused;
+		// everywhere else they cycle focus, same as tab/shift+tab.
+		if m.focusIndex == presetFocusIndex {
+			var cmd tea.Cmd
+			m.presetList, cmd = m.presetList.Update(msg)
+			return m, cmd
 		}
+		return m.cycleFocus(msg.String() == "up")
+
+	case "tab", "shift+tab":
+		return m.cycleFocus(msg.String() == "shift+tab")
+	}
+
+	// Handle character input for focused field
+	cmd := m.updateInputs(msg)
+	return m, cmd
+}
+
+// cycleFocus moves focus between the menu's inputs, preset list, and start
+// button, wrapping around at either end.
+func (m model) cycleFocus(backward bool) (tea.Model, tea.Cmd) {
+	if backward {
+		m.focusIndex--
+	} else {
+		m.focusIndex++
+	}
+
+	if m.focusIndex > startFocusIndex {
+		m.focusIndex = 0
+	} else if m.focusIndex < 0 {
+		m.focusIndex = startFocusIndex
+	}
 
-		// Cycle through inputs
-		if s == "up" || s == "shift+tab" {
-			m.focusIndex--
+	cmds := make([]tea.Cmd, len(m.inputs))
+	for i := range m.inputs {
+		if i == m.focusIndex {
+			cmds[i] = m.inputs[i].Focus()
+			m.inputs[i].PromptStyle = focusedStyle
+			m.inputs[i].TextStyle = focusedStyle
 		} else {
-			m.focusIndex++
+			m.inputs[i].Blur()
+			m.inputs[i].PromptStyle = noStyle
+			m.inputs[i].TextStyle = noStyle
 		}
+	}
 
-		if m.focusIndex > len(m.inputs) {
-			m.focusIndex = 0
-		} else if m.focusIndex < 0 {
-			m.focusIndex = len(m.inputs)
-		}
+	return m, tea.Batch(cmds...)
+}
 
-		cmds := make([]tea.Cmd, len(m.inputs))
-		for i := 0; i <= len(m.inputs)-1; i++ {
-			if i == m.focusIndex {
-				cmds[i] = m.inputs[i].Focus()
-				m.inputs[i].PromptStyle = focusedStyle
-				m.inputs[i].TextStyle = focusedStyle
-			} else {
-				m.inputs[i].Blur()
-				m.inputs[i].PromptStyle = noStyle
-				m.inputs[i].TextStyle = noStyle
+// applyPreset fills the interval/duration/output inputs from p, leaving any
+// field the preset doesn't set untouched, and pushes codec/region onto the
+// active capturer if it's one that understands them.
+func (m model) applyPreset(p Preset) model {
+	if p.Interval != "" {
+		m.inputs[0].SetValue(p.Interval)
+	}
+	if p.Duration != "" {
+		m.inputs[1].SetValue(p.Duration)
+	}
+	if p.OutputTemplate != "" {
+		m.inputs[2].SetValue(p.OutputTemplate)
+	}
+
+	if sc, ok := m.capturer.(*ScreenCapturer); ok {
+		if p.Codec != "" {
+			sc.Codec = p.Codec
+		}
+		if p.Region != "" {
+			if region, err := parseRegion(p.Region); err == nil {
+				sc.Region = region
 			}
 		}
-
-		return m, tea.Batch(cmds...)
 	}
 
-	// Handle character input for focused field
-	cmd := m.updateInputs(msg)
-	return m, cmd
+	m.activePreset = p.Name
+	return m
 }
 
 func (m model) updateRecording(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "q", "ctrl+c":
-		// Stop recording
-		if m.cmd != nil && m.cmd.Process != nil {
-			m.cmd.Process.Signal(os.Interrupt)
+		if m.registry != nil && !m.authorized {
+			return m, nil
 		}
+		m.controlCh <- controlStop
 		return m, nil
+
+	case "f":
+		m.follow = !m.follow
+		if m.follow {
+			m.logViewport.GotoBottom()
+		}
+		return m, nil
+
+	case "p":
+		if m.registry != nil && !m.authorized {
+			return m, nil
+		}
+		return m, func() tea.Msg { return pauseMsg{} }
+
+	case "r":
+		if m.registry != nil && !m.authorized {
+			return m, nil
+		}
+		return m, func() tea.Msg { return resumeMsg{} }
+
+	case "s":
+		if m.registry != nil && !m.authorized {
+			return m, nil
+		}
+		return m, func() tea.Msg { return stepMsg{} }
+
+	case "j", "k", "up", "down", "pgup", "pgdown":
+		// Manual scrolling breaks follow, same as the free/select-scroll
+		// split in Bob's tui.go.
+		m.follow = false
+		var cmd tea.Cmd
+		m.logViewport, cmd = m.logViewport.Update(msg)
+		return m, cmd
 	}
 	return m, nil
 }
@@ -298,13 +574,45 @@ func (m model) startRecording() (tea.Model, tea.Cmd) {
 	if output == "" {
 		output = "timelapse.mp4"
 	}
+	output, err = resolveOutput(output, m.activePreset, time.Now())
+	if err != nil {
+		m.state = stateError
+		m.finalMessage = fmt.Sprintf("Invalid output template: %v", err)
+		return m, nil
+	}
 
 	// Change state to recording
 	m.state = stateRecording
 	m.startTime = time.Now()
+	m.captureInterval = intervalFloat
 	m.recordingDone = false
+	m.quantum = quantumRunning
+	m.pausedDuration = 0
+	m.pauseStart = time.Time{}
+	m.lastFrameTime = time.Time{}
+	m.ewmaInterval = 0
+	m.progress = progressMsg{}
+	m.progressBar.SetPercent(0)
+	// SSH-served sessions join a shared recording (see serve.go) instead of
+	// starting their own: the registry runs captureFrameLoop once and fans
+	// its messages out to every subscriber, starting the capture only for
+	// the first session to reach here.
+	if m.registry != nil {
+		m.registryCh = m.registry.Subscribe(m.ctx)
+
+		var params recordingParams
+		m.controlCh, params = m.registry.Start(m.capturer, intervalFloat, durationFloat, output)
+		m.captureInterval = params.interval
+
+		return m, tea.Batch(
+			m.spinner.Tick,
+			tick(),
+			waitForRegistry(m.registryCh),
+		)
+	}
+
+	m.controlCh = make(chan controlSignal, 4)
 
-	// Start the Python subprocess
 	return m, tea.Batch(
 		m.spinner.Tick,
 		tick(),
@@ -312,84 +620,105 @@ func (m model) startRecording() (tea.Model, tea.Cmd) {
 	)
 }
 
+// runTimelapse drives m.capturer to produce output. Backends that implement
+// fullRunCapturer (the legacy Python script) own the whole capture+encode
+// session themselves and ignore pause/resume/step/stop; everything else is
+// driven frame-by-frame through the generic Capturer interface, with
+// m.controlCh steering the loop's quantum.
 func (m model) runTimelapse(interval, duration float64, output string) tea.Cmd {
 	return func() tea.Msg {
-		// Build command
-		cmd := exec.Command(
-			"python3",
-			"timelapse.py",
-			"-i", fmt.Sprintf("%.2f", interval),
-			"-d", fmt.Sprintf("%.2f", duration),
-			"-o", output,
-		)
-
-		// Get stdout and stderr pipes
-		stdout, err := cmd.StdoutPipe()
-		if err != nil {
-			return completedMsg{false, fmt.Sprintf("Failed to create stdout pipe: %v", err)}
+		if fr, ok := m.capturer.(fullRunCapturer); ok {
+			return fr.run(interval, duration, output, program.Send)
 		}
+		return captureFrameLoop(m.capturer, interval, duration, output, m.controlCh, program.Send)
+	}
+}
 
-		stderr, err := cmd.StderrPipe()
-		if err != nil {
-			return completedMsg{false, fmt.Sprintf("Failed to create stderr pipe: %v", err)}
-		}
+// captureFrameLoop drives any plain Capturer. On each tick of interval it
+// fires CaptureFrame, staging PNGs in a temp directory, until duration
+// elapses; it then hands the ordered frame list to Encode. controlCh steers
+// the loop between running and paused, can force an immediate step, and can
+// stop the loop early (Encode still runs over whatever frames were already
+// captured). sink delivers progress/quantum messages as they happen; the
+// local TUI wires it to program.Send, while an SSH-served recording wires
+// it to a recorderRegistry so every attached viewer sees the same updates.
+func captureFrameLoop(capturer Capturer, interval, duration float64, output string, controlCh chan controlSignal, sink func(tea.Msg)) tea.Msg {
+	ctx := context.Background()
+
+	total := int(duration / interval)
+	if total < 1 {
+		total = 1
+	}
 
-		// Start the command
-		if err := cmd.Start(); err != nil {
-			return completedMsg{false, fmt.Sprintf("Failed to start process: %v", err)}
-		}
+	frameDir, err := os.MkdirTemp("", "chronapse-frames-")
+	if err != nil {
+		return completedMsg{success: false, message: fmt.Sprintf("Failed to create frame dir: %v", err)}
+	}
+	defer os.RemoveAll(frameDir)
 
-		// Read output in goroutines
-		go m.readOutput(stdout)
-		go m.readOutput(stderr)
+	ticker := time.NewTicker(time.Duration(interval * float64(time.Second)))
+	defer ticker.Stop()
 
-		// Wait for completion
-		err = cmd.Wait()
+	frames := make([]string, 0, total)
+	captureOne := func(frameIdx int) error {
+		img, err := capturer.CaptureFrame(ctx, frameIdx)
+		if err != nil {
+			return err
+		}
 
+		path, err := saveFrame(frameDir, frameIdx, img)
 		if err != nil {
-			return completedMsg{false, fmt.Sprintf("Recording failed: %v", err)}
+			return err
 		}
+		frames = append(frames, path)
 
-		return completedMsg{true, fmt.Sprintf("Timelapse saved to: %s", output)}
+		percent := float64(frameIdx+1) / float64(total) * 100
+		sink(progressMsg{frameIdx + 1, total, percent})
+		return nil
 	}
-}
 
-func (m model) readOutput(pipe io.ReadCloser) tea.Cmd {
-	scanner := bufio.NewScanner(pipe)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// Parse progress messages
-		if strings.Contains(line, "[PROGRESS]") {
-			// Format: [PROGRESS] 5/120 (4.2%)
-			parts := strings.Fields(line)
-			if len(parts) >= 2 {
-				counts := strings.Split(parts[1], "/")
-				if len(counts) == 2 {
-					current, _ := strconv.Atoi(counts[0])
-					total, _ := strconv.Atoi(counts[1])
-					percent := 0.0
-					if len(parts) >= 3 {
-						percentStr := strings.Trim(parts[2], "(%))")
-						percent, _ = strconv.ParseFloat(percentStr, 64)
-					}
-
-					// Send progress message
-					go func() {
-						tea.Printf("%v", progressMsg{current, total, percent})
-					}()
+	paused := false
+	stopped := false
+captureLoop:
+	for frameIdx := 0; frameIdx < total; {
+		select {
+		case sig := <-controlCh:
+			switch sig {
+			case controlPause:
+				paused = true
+				sink(quantumMsg(quantumPaused))
+			case controlResume:
+				paused = false
+				sink(quantumMsg(quantumRunning))
+			case controlStep:
+				if err := captureOne(frameIdx); err != nil {
+					return completedMsg{success: false, message: fmt.Sprintf("Capture failed: %v", err)}
 				}
+				frameIdx++
+			case controlStop:
+				stopped = true
+				break captureLoop
+			}
+
+		case <-ticker.C:
+			if paused {
+				continue
 			}
+			if err := captureOne(frameIdx); err != nil {
+				return completedMsg{success: false, message: fmt.Sprintf("Capture failed: %v", err)}
+			}
+			frameIdx++
 		}
+	}
 
-		// Send log message for all lines
-		go func(l string) {
-			tea.Printf("%v", logMsg(l))
-		}(line)
+	if err := capturer.Encode(frames, output); err != nil {
+		return completedMsg{success: false, message: fmt.Sprintf("Encoding failed: %v", err)}
 	}
 
-	return nil
+	if stopped {
+		return completedMsg{success: true, message: fmt.Sprintf("Recording stopped early, %d frames saved to: %s", len(frames), output), outputPath: output}
+	}
+	return completedMsg{success: true, message: fmt.Sprintf("Timelapse saved to: %s", output), outputPath: output}
 }
 
 func tick() tea.Cmd {
@@ -448,54 +777,87 @@ func (m model) viewMenu() string {
 	b.WriteString(label + "\n")
 	b.WriteString(m.inputs[2].View() + "\n\n")
 
+	// Preset selector
+	if len(m.presets) > 0 {
+		label = "Presets (enter applies, then starts):"
+		if m.focusIndex == presetFocusIndex {
+			label = focusedStyle.Render("▸ " + label)
+		} else {
+			label = blurredStyle.Render("  " + label)
+		}
+		b.WriteString(label + "\n")
+		b.WriteString(m.presetList.View() + "\n\n")
+	}
+
 	// Start button
 	button := "[ Start Recording ]"
-	if m.focusIndex == 3 {
+	if m.focusIndex == startFocusIndex {
 		button = focusedStyle.Render("▸ " + button)
 	} else {
 		button = blurredStyle.Render("  " + button)
 	}
 	b.WriteString(button + "\n")
 
-	b.WriteString(helpStyle.Render("\nTab: next • Enter: start • Ctrl+C: quit"))
+	b.WriteString(helpStyle.Render("\n" + m.help.View(keyMapFor(m.state))))
 
 	return "\n" + b.String() + "\n"
 }
 
+// eta estimates time remaining from the EWMA-smoothed per-frame interval, so
+// a few dropped or delayed frames don't make the estimate jump around; it
+// falls back to the requested capture interval until enough frames have
+// landed to smooth over.
+func (m model) eta() string {
+	remaining := m.progress.total - m.progress.current
+	if remaining <= 0 {
+		return "0s"
+	}
+
+	interval := m.ewmaInterval
+	if interval == 0 {
+		interval = time.Duration(m.captureInterval * float64(time.Second))
+	}
+
+	return (time.Duration(remaining) * interval).Round(time.Second).String()
+}
+
 func (m model) viewRecording() string {
 	var b strings.Builder
 
-	elapsed := time.Since(m.startTime)
+	paused := m.pausedDuration
+	if m.quantum == quantumPaused && !m.pauseStart.IsZero() {
+		paused += time.Since(m.pauseStart)
+	}
+	elapsed := time.Since(m.startTime) - paused
 
 	b.WriteString(titleStyle.Render("⏱  Recording in Progress"))
 	b.WriteString("\n\n")
 
-	b.WriteString(fmt.Sprintf("%s Recording...\n\n", m.spinner.View()))
+	if m.quantum == quantumPaused {
+		b.WriteString(progressStyle.Render("⏸ Paused") + "\n\n")
+	} else {
+		b.WriteString(fmt.Sprintf("%s Recording...\n\n", m.spinner.View()))
+	}
 
 	// Progress bar
 	if m.progress.total > 0 {
-		barWidth := 40
-		filled := int(float64(barWidth) * m.progress.percent / 100.0)
-		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
-
-		b.WriteString(progressStyle.Render(fmt.Sprintf("Progress: [%s] %.1f%%\n", bar, m.progress.percent)))
-		b.WriteString(progressStyle.Render(fmt.Sprintf("Frames:   %d / %d\n", m.progress.current, m.progress.total)))
+		b.WriteString(m.progressBar.View() + "\n")
+		b.WriteString(progressStyle.Render(fmt.Sprintf("Frames: %d / %d   ETA: %s\n", m.progress.current, m.progress.total, m.eta())))
 	}
 
 	b.WriteString(fmt.Sprintf("\nElapsed:  %s\n", elapsed.Round(time.Second)))
 
-	// Show recent logs
+	// Scrollable log history
 	if len(m.logs) > 0 {
-		b.WriteString("\n" + logStyle.Render("Recent activity:") + "\n")
-		for _, log := range m.logs {
-			if len(log) > 80 {
-				log = log[:77] + "..."
-			}
-			b.WriteString(logStyle.Render("  "+log) + "\n")
+		followState := "free scroll"
+		if m.follow {
+			followState = "following"
 		}
+		b.WriteString("\n" + logStyle.Render(fmt.Sprintf("Activity (%s):", followState)) + "\n")
+		b.WriteString(logStyle.Render(m.logViewport.View()) + "\n")
 	}
 
-	b.WriteString(helpStyle.Render("\nPress 'q' to stop recording"))
+	b.WriteString(helpStyle.Render("\n" + m.help.View(keyMapFor(m.state))))
 
 	return "\n" + b.String() + "\n"
 }
@@ -503,23 +865,70 @@ func (m model) viewRecording() string {
 func (m model) viewCompleted() string {
 	var b strings.Builder
 
-	elapsed := time.Since(m.startTime)
-
 	b.WriteString(titleStyle.Render("✓ Recording Complete"))
 	b.WriteString("\n\n")
 
 	b.WriteString(successStyle.Render(m.finalMessage))
 	b.WriteString("\n\n")
 
-	b.WriteString(fmt.Sprintf("Total time: %s\n", elapsed.Round(time.Second)))
+	for _, row := range m.summaryRows() {
+		b.WriteString(fmt.Sprintf("%-18s %s\n", row[0]+":", row[1]))
+	}
+
+	b.WriteString(helpStyle.Render("\n" + m.help.View(keyMapFor(m.state))))
+
+	return "\n" + b.String() + "\n"
+}
+
+// summaryRows builds the label/value pairs viewCompleted renders as a small
+// table: frames captured vs. expected, average capture interval, wall-clock
+// elapsed, encoded duration, and the finished file's size on disk.
+func (m model) summaryRows() [][2]string {
+	elapsed := time.Since(m.startTime) - m.pausedDuration
+
+	rows := [][2]string{
+		{"Wall clock", elapsed.Round(time.Second).String()},
+	}
 
 	if m.progress.total > 0 {
-		b.WriteString(fmt.Sprintf("Frames captured: %d\n", m.progress.current))
+		dropped := m.progress.total - m.progress.current
+		rows = append(rows,
+			[2]string{"Frames captured", fmt.Sprintf("%d / %d", m.progress.current, m.progress.total)},
+			[2]string{"Frames dropped", fmt.Sprintf("%d", dropped)},
+		)
+
+		if m.progress.current > 0 {
+			avgInterval := elapsed / time.Duration(m.progress.current)
+			encoded := time.Duration(m.progress.current) * time.Duration(m.captureInterval*float64(time.Second))
+			rows = append(rows,
+				[2]string{"Avg interval", avgInterval.Round(time.Millisecond).String()},
+				[2]string{"Encoded duration", encoded.Round(time.Second).String()},
+			)
+		}
+	}
+
+	if m.outputPath != "" {
+		if info, err := os.Stat(m.outputPath); err == nil {
+			rows = append(rows, [2]string{"Output size", formatBytes(info.Size())})
+		}
 	}
 
-	b.WriteString(helpStyle.Render("\nPress 'q' to quit"))
+	return rows
+}
 
-	return "\n" + b.String() + "\n"
+// formatBytes renders n as a human-readable size (KB/MB/GB), matching the
+// precision other summary fields use.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
 }
 
 func (m model) viewError() string {
@@ -531,14 +940,51 @@ func (m model) viewError() string {
 	b.WriteString(errorStyle.Render(m.finalMessage))
 	b.WriteString("\n")
 
-	b.WriteString(helpStyle.Render("\nPress 'q' to quit"))
+	b.WriteString(helpStyle.Render("\n" + m.help.View(keyMapFor(m.state))))
 
 	return "\n" + b.String() + "\n"
 }
 
 func main() {
-	p := tea.NewProgram(initialModel())
-	if _, err := p.Run(); err != nil {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(os.Args[2:])
+		return
+	}
+
+	backend := flag.String("backend", "native", "capture backend: native (default, zero-dependency) or python (legacy timelapse.py)")
+	flag.Parse()
+
+	var capturer Capturer
+	switch *backend {
+	case "native":
+		capturer = NewScreenCapturer()
+	case "python":
+		capturer = NewPythonCapturer()
+	default:
+		fmt.Printf("Error: unknown backend %q (want native or python)\n", *backend)
+		os.Exit(1)
+	}
+
+	presetsPath, err := presetsConfigPath()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	presets, err := loadPresets(presetsPath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	program = tea.NewProgram(initialModel(capturer, presets))
+
+	if err := watchPresets(presetsPath, program.Send); err != nil {
+		// Hot reload is a nicety, not a dependency: keep running on the
+		// presets we already loaded if the watcher can't be set up.
+		program.Send(printAbove(fmt.Sprintf("presets: %v", err))())
+	}
+
+	if _, err := program.Run(); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
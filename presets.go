@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Preset is one named capture profile loaded from presets.toml.
+type Preset struct {
+	Name           string `toml:"name"`
+	Interval       string `toml:"interval"`
+	Duration       string `toml:"duration"`
+	OutputTemplate string `toml:"output_template"`
+	Codec          string `toml:"codec"`
+	Region         string `toml:"region"`
+}
+
+// Title and Description satisfy list.DefaultDelegate's item interface.
+func (p Preset) Title() string { return p.Name }
+func (p Preset) Description() string {
+	return fmt.Sprintf("%ss every %ss, %s", p.Duration, p.Interval, p.OutputTemplate)
+}
+func (p Preset) FilterValue() string { return p.Name }
+
+// presetsConfigPath returns the location of presets.toml under the user's
+// config directory, creating the chronapse subdirectory if needed.
+func presetsConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	chronapseDir := filepath.Join(dir, "chronapse")
+	if err := os.MkdirAll(chronapseDir, 0o755); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(chronapseDir, "presets.toml"), nil
+}
+
+// loadPresets parses presets.toml at path. A missing file is not an error:
+// it just means no presets are configured yet.
+func loadPresets(path string) ([]Preset, error) {
+	var cfg struct {
+		Presets []Preset `toml:"preset"`
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return nil, fmt.Errorf("parse presets: %w", err)
+	}
+
+	return cfg.Presets, nil
+}
+
+// presetsReloadedMsg carries the result of a presets.toml (re)load, whether
+// triggered at startup or by the fsnotify watcher noticing an edit.
+type presetsReloadedMsg struct {
+	presets []Preset
+	err     error
+}
+
+// watchPresets watches path's directory for changes (editors typically
+// replace a file rather than write it in place, so a plain file watch would
+// miss most edits) and delivers a presetsReloadedMsg through sink for every
+// change affecting path. sink lets callers route the message correctly
+// instead of assuming a single global program: the local TUI wires it to
+// program.Send, while `chronapse serve` fans it out to every connected
+// session via a presetRegistry.
+func watchPresets(path string, sink func(tea.Msg)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create config watcher: %w", err)
+	}
+
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("watch config dir: %w", err)
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+
+				presets, err := loadPresets(path)
+				sink(presetsReloadedMsg{presets: presets, err: err})
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				sink(presetsReloadedMsg{err: fmt.Errorf("watch config: %w", err)})
+			}
+		}
+	}()
+
+	return nil
+}
+
+// presetItems adapts presets to the list.Item slice bubbles/list expects.
+func presetItems(presets []Preset) []list.Item {
+	items := make([]list.Item, len(presets))
+	for i, p := range presets {
+		items[i] = p
+	}
+	return items
+}
+
+// newPresetList builds the bubbles/list used as the menu's preset selector.
+func newPresetList(presets []Preset, width, height int) list.Model {
+	l := list.New(presetItems(presets), list.NewDefaultDelegate(), width, height)
+	l.Title = "Presets"
+	l.SetShowHelp(false)
+	l.SetShowStatusBar(false)
+	return l
+}
+
+// templateData is what {{.Date}}/{{.Preset}}-style output placeholders can
+// reference, filled in once at recording start.
+type templateData struct {
+	Date   string
+	Preset string
+}
+
+// resolveOutput evaluates raw as a text/template using the given preset name
+// and the current date, so output fields like "{{.Date}}_{{.Preset}}.mp4"
+// expand at recording start. Templates without any "{{" are returned as-is.
+func resolveOutput(raw, presetName string, now time.Time) (string, error) {
+	if !bytes.Contains([]byte(raw), []byte("{{")) {
+		return raw, nil
+	}
+
+	tmpl, err := template.New("output").Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("parse output template: %w", err)
+	}
+
+	data := templateData{
+		Date:   now.Format("2006-01-02"),
+		Preset: presetName,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("execute output template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// parseRegion parses a preset's "region" field, formatted "x,y,width,height",
+// into the rectangle ScreenCapturer should crop capture to.
+func parseRegion(spec string) (image.Rectangle, error) {
+	parts := strings.Split(spec, ",")
+	if len(parts) != 4 {
+		return image.Rectangle{}, fmt.Errorf("region %q: want \"x,y,width,height\"", spec)
+	}
+
+	vals := make([]int, 4)
+	for i, p := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return image.Rectangle{}, fmt.Errorf("region %q: %w", spec, err)
+		}
+		vals[i] = v
+	}
+
+	x, y, w, h := vals[0], vals[1], vals[2], vals[3]
+	if w <= 0 || h <= 0 {
+		return image.Rectangle{}, fmt.Errorf("region %q: width and height must be positive", spec)
+	}
+
+	return image.Rect(x, y, x+w, y+h), nil
+}
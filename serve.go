@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	bm "github.com/charmbracelet/wish/bubbletea"
+	lm "github.com/charmbracelet/wish/logging"
+)
+
+// recorderRegistry is the one active recording a `chronapse serve` process
+// hosts at a time. Every connected SSH session gets its own model, but they
+// all Subscribe to the same registry so they observe the same capture
+// instead of each spawning a competing one; the first session to call Start
+// owns the underlying captureFrameLoop and the rest just watch.
+type recorderRegistry struct {
+	mu          sync.Mutex
+	active      bool
+	controlCh   chan controlSignal
+	params      recordingParams
+	subscribers map[chan tea.Msg]struct{}
+}
+
+// recordingParams is the interval/duration/output a shared recording is
+// actually running with. A session that joins via Start after the first one
+// already started gets these back instead of the values it typed in, so it
+// can reconcile a model that otherwise displays stats (like summaryRows'
+// encoded duration) unrelated to the file actually being produced.
+type recordingParams struct {
+	interval float64
+	duration float64
+	output   string
+}
+
+// newRecorderRegistry returns an empty registry with no active recording.
+func newRecorderRegistry() *recorderRegistry {
+	return &recorderRegistry{
+		subscribers: make(map[chan tea.Msg]struct{}),
+	}
+}
+
+// Subscribe registers a new viewer and returns the channel its session
+// should read broadcast messages from. The channel is removed automatically
+// once ctx is done (the SSH session closing), so callers don't need to call
+// Unsubscribe themselves.
+func (r *recorderRegistry) Subscribe(ctx context.Context) chan tea.Msg {
+	ch := make(chan tea.Msg, 16)
+
+	r.mu.Lock()
+	r.subscribers[ch] = struct{}{}
+	r.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		r.Unsubscribe(ch)
+	}()
+
+	return ch
+}
+
+// Unsubscribe removes a viewer's channel and closes it, for when its SSH
+// session ends; closing after the delete (so broadcast can no longer pick
+// it up) is what lets waitForRegistry's `msg, ok := <-ch` trip and return.
+func (r *recorderRegistry) Unsubscribe(ch chan tea.Msg) {
+	r.mu.Lock()
+	delete(r.subscribers, ch)
+	close(ch)
+	r.mu.Unlock()
+}
+
+// broadcast delivers msg to every current subscriber. Slow or gone viewers
+// never block the capture loop: a full subscriber channel just drops msg.
+func (r *recorderRegistry) broadcast(msg tea.Msg) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for ch := range r.subscribers {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+// Start begins the shared recording the first time it's called and returns
+// its control channel plus the interval/duration/output actually driving it;
+// later callers (other viewers reaching startRecording while the recording
+// is already running) get that same channel and those same params back
+// without starting a second capture.
+func (r *recorderRegistry) Start(capturer Capturer, interval, duration float64, output string) (chan controlSignal, recordingParams) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.active {
+		return r.controlCh, r.params
+	}
+
+	r.active = true
+	r.params = recordingParams{interval, duration, output}
+	r.controlCh = make(chan controlSignal, 4)
+
+	go func() {
+		var msg tea.Msg
+		if fr, ok := capturer.(fullRunCapturer); ok {
+			msg = fr.run(interval, duration, output, r.broadcast)
+		} else {
+			msg = captureFrameLoop(capturer, interval, duration, output, r.controlCh, r.broadcast)
+		}
+		r.broadcast(msg)
+
+		r.mu.Lock()
+		r.active = false
+		r.mu.Unlock()
+	}()
+
+	return r.controlCh, r.params
+}
+
+// waitForRegistry blocks for the next message broadcast by a recorderRegistry
+// and re-arms itself so the session keeps receiving updates for as long as
+// registryCh stays open.
+func waitForRegistry(ch chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return registryEventMsg{msg: msg}
+	}
+}
+
+// presetRegistry fans presets.toml reload notifications out to every
+// connected SSH session, independently of recorderRegistry: a session should
+// pick up a config change whether or not it's currently watching a
+// recording, so it subscribes as soon as it connects (see teaHandler) rather
+// than only once it reaches startRecording.
+type presetRegistry struct {
+	mu   sync.Mutex
+	subs map[chan tea.Msg]struct{}
+}
+
+// newPresetRegistry returns an empty registry with no subscribers.
+func newPresetRegistry() *presetRegistry {
+	return &presetRegistry{subs: make(map[chan tea.Msg]struct{})}
+}
+
+// Subscribe registers a new session and returns the channel it should read
+// reload notifications from. The channel is removed and closed automatically
+// once ctx is done (the SSH session closing).
+func (r *presetRegistry) Subscribe(ctx context.Context) chan tea.Msg {
+	ch := make(chan tea.Msg, 4)
+
+	r.mu.Lock()
+	r.subs[ch] = struct{}{}
+	r.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		r.Unsubscribe(ch)
+	}()
+
+	return ch
+}
+
+// Unsubscribe removes a session's channel and closes it, for when its SSH
+// session ends.
+func (r *presetRegistry) Unsubscribe(ch chan tea.Msg) {
+	r.mu.Lock()
+	delete(r.subs, ch)
+	close(ch)
+	r.mu.Unlock()
+}
+
+// broadcast delivers msg to every current subscriber. Slow or gone sessions
+// never block the watcher: a full subscriber channel just drops msg.
+func (r *presetRegistry) broadcast(msg tea.Msg) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for ch := range r.subs {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+// waitForPresets blocks for the next message broadcast by a presetRegistry
+// and re-arms itself so the session keeps receiving reloads for as long as
+// presetsCh stays open.
+func waitForPresets(ch chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return presetEventMsg{msg: msg}
+	}
+}
+
+// teaHandler builds the bm.Handler shared by every SSH session: each gets
+// its own model attached to the same registry and capturer, so one viewer's
+// keypresses can pause/resume/step the one recording everyone is watching,
+// and each subscribes to presetReg right away so it hears presets.toml
+// reloads regardless of what it's doing.
+func teaHandler(registry *recorderRegistry, presetReg *presetRegistry, capturer Capturer, presets []Preset, authorized func(ssh.Session) bool) bm.Handler {
+	return func(s ssh.Session) (tea.Model, []tea.ProgramOption) {
+		m := initialModel(capturer, presets)
+		m.registry = registry
+		m.authorized = authorized(s)
+		m.ctx = s.Context()
+		m.presetsCh = presetReg.Subscribe(m.ctx)
+
+		return m, bm.MakeOptions(s)
+	}
+}
+
+// runServe runs `chronapse serve`: a Wish SSH server that hosts the same
+// Bubble Tea model as the local TUI, but with every connected session
+// watching (and, if authorized, steering) one shared recording.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":2222", "address to listen on")
+	hostKeyPath := fs.String("host-key", ".ssh/chronapse_ed25519", "path to the server's SSH host key")
+	authorizedKeysPath := fs.String("authorized-keys", "", "path to an authorized_keys file; if unset, every connection may pause/resume/step")
+	backend := fs.String("backend", "native", "capture backend: native (default, zero-dependency) or python (legacy timelapse.py)")
+	fs.Parse(args)
+
+	var capturer Capturer
+	switch *backend {
+	case "native":
+		capturer = NewScreenCapturer()
+	case "python":
+		capturer = NewPythonCapturer()
+	default:
+		fmt.Printf("Error: unknown backend %q (want native or python)\n", *backend)
+		os.Exit(1)
+	}
+
+	presetsPath, err := presetsConfigPath()
+	if err != nil {
+		log.Fatalf("could not locate presets config: %v", err)
+	}
+	presets, err := loadPresets(presetsPath)
+	if err != nil {
+		log.Fatalf("could not load presets: %v", err)
+	}
+
+	registry := newRecorderRegistry()
+
+	presetReg := newPresetRegistry()
+	if err := watchPresets(presetsPath, presetReg.broadcast); err != nil {
+		// Hot reload is a nicety, not a dependency: keep running on the
+		// presets we already loaded if the watcher can't be set up.
+		log.Printf("presets: %v", err)
+	}
+
+	authorized := func(s ssh.Session) bool { return true }
+	opts := []ssh.Option{
+		wish.WithAddress(*addr),
+		wish.WithHostKeyPath(*hostKeyPath),
+	}
+	if *authorizedKeysPath != "" {
+		opts = append(opts, wish.WithAuthorizedKeys(*authorizedKeysPath))
+		authorized = func(s ssh.Session) bool { return s.PublicKey() != nil }
+	}
+	opts = append(opts, wish.WithMiddleware(
+		bm.Middleware(teaHandler(registry, presetReg, capturer, presets, authorized)),
+		lm.Middleware(),
+	))
+
+	s, err := wish.NewServer(opts...)
+	if err != nil {
+		log.Fatalf("could not start server: %v", err)
+	}
+
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
+
+	log.Printf("starting SSH server on %s", *addr)
+	go func() {
+		if err := s.ListenAndServe(); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+			log.Fatalf("could not start server: %v", err)
+		}
+	}()
+
+	<-done
+	log.Println("stopping SSH server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		log.Fatalf("could not stop server gracefully: %v", err)
+	}
+}